@@ -0,0 +1,125 @@
+// Package apperr defines the typed application errors shared by every
+// transport, so that HTTP and gRPC can each translate the same error into
+// their own status representation instead of collapsing everything to a
+// generic 500.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// Code identifies the class of failure independently of any transport.
+type Code string
+
+const (
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+	CodeAlreadyExists    Code = "ALREADY_EXISTS"
+	CodeNotFound         Code = "NOT_FOUND"
+	CodeConflict         Code = "CONFLICT"
+	CodeInternal         Code = "INTERNAL"
+	CodeUnauthenticated  Code = "UNAUTHENTICATED"
+	CodeForbidden        Code = "FORBIDDEN"
+)
+
+// FieldError reports one struct field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
+// Error is the typed error carried through the repo/service/transport
+// layers. It keeps the original cause and the call site so logs stay
+// actionable without leaking either detail to API clients.
+type Error struct {
+	Code    Code
+	Message string
+	Cause   error
+	File    string
+	Line    int
+	// Fields carries the per-field validation failures for
+	// CodeValidationFailed errors produced by the validation package. It is
+	// nil for every other error.
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %s: %s", e.Code, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// New creates an *Error with the caller's file/line already captured.
+func New(code Code, message string) *Error {
+	return newAt(code, message, nil, 2)
+}
+
+// Wrap creates an *Error that keeps cause as its wrapped error.
+func Wrap(code Code, message string, cause error) *Error {
+	return newAt(code, message, cause, 2)
+}
+
+func newAt(code Code, message string, cause error, skip int) *Error {
+	file, line := "", 0
+	if _, f, l, ok := runtime.Caller(skip); ok {
+		file, line = f, l
+	}
+
+	return &Error{Code: code, Message: message, Cause: cause, File: file, Line: line}
+}
+
+// ValidationFailed is a convenience constructor for CodeValidationFailed.
+func ValidationFailed(message string) *Error {
+	return newAt(CodeValidationFailed, message, nil, 2)
+}
+
+// ValidationFailedFields is a CodeValidationFailed error carrying the
+// individual fields that failed, as reported by the validation package.
+func ValidationFailedFields(message string, fields []FieldError) *Error {
+	err := newAt(CodeValidationFailed, message, nil, 2)
+	err.Fields = fields
+	return err
+}
+
+// AlreadyExists is a convenience constructor for CodeAlreadyExists.
+func AlreadyExists(message string) *Error {
+	return newAt(CodeAlreadyExists, message, nil, 2)
+}
+
+// NotFound is a convenience constructor for CodeNotFound.
+func NotFound(message string) *Error {
+	return newAt(CodeNotFound, message, nil, 2)
+}
+
+// Internal wraps cause as an opaque CodeInternal error.
+func Internal(message string, cause error) *Error {
+	return newAt(CodeInternal, message, cause, 2)
+}
+
+// Unauthenticated is a convenience constructor for CodeUnauthenticated.
+func Unauthenticated(message string) *Error {
+	return newAt(CodeUnauthenticated, message, nil, 2)
+}
+
+// Forbidden is a convenience constructor for CodeForbidden, used when the
+// caller is authenticated but lacks the role required for the operation.
+func Forbidden(message string) *Error {
+	return newAt(CodeForbidden, message, nil, 2)
+}
+
+// CodeOf returns the Code carried by err, or CodeInternal if err is not (or
+// does not wrap) an *Error.
+func CodeOf(err error) Code {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return CodeInternal
+}
@@ -0,0 +1,94 @@
+// Package auth issues and verifies the JWTs used to authenticate requests,
+// and carries the resulting claims through a request's context so both
+// transports can enforce the same role-based policy.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+)
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Claims is the JWT payload: the standard registered claims plus the user's
+// role.
+type Claims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// TokenIssuer issues and parses the HS256 JWTs used across both transports.
+type TokenIssuer interface {
+	Issue(userID int, role string) (string, error)
+	Parse(token string) (*Claims, error)
+}
+
+type tokenIssuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewTokenIssuer returns a TokenIssuer signing with secret and setting exp to
+// now+ttl.
+func NewTokenIssuer(secret string, ttl time.Duration) TokenIssuer {
+	return &tokenIssuer{secret: []byte(secret), ttl: ttl}
+}
+
+func (t *tokenIssuer) Issue(userID int, role string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(userID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(t.ttl)),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(t.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (t *tokenIssuer) Parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return t.secret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, apperr.Unauthenticated("невалидный или просроченный токен")
+	}
+
+	return claims, nil
+}
+
+type claimsContextKey struct{}
+
+// ContextWithClaims attaches claims to ctx, for transports to read back via
+// ClaimsFromContext.
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims attached by the auth middleware, if
+// any.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+)
+
+// HashPassword returns the bcrypt hash of password, for storage in place of
+// the plaintext value.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// ComparePassword reports whether password matches hash, returning an
+// apperr.CodeUnauthenticated error when it does not.
+func ComparePassword(hash, password string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return apperr.Unauthenticated("неверный email или пароль")
+	}
+	return nil
+}
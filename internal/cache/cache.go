@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache is the Proxy-pattern backing store used in front of the repo layer.
+type Cache interface {
+	Set(ctx context.Context, key string, value interface{}) error
+	Get(ctx context.Context, key string, ptrValue interface{}) error
+	Delete(ctx context.Context, key string) error
+	// Increment atomically bumps key by 1 and returns the new value. It is
+	// used as a version counter: callers fold the version into the cache key
+	// instead of scanning/deleting entries on invalidation.
+	Increment(ctx context.Context, key string) (int64, error)
+}
+
+type cache struct {
+	client         *redis.Client
+	dataExpiration time.Duration
+}
+
+func NewCache(cc *redis.Client, dataExpiration time.Duration) Cache {
+	return &cache{cc, dataExpiration}
+}
+
+func (c *cache) Set(ctx context.Context, key string, value interface{}) error {
+	var data []byte
+	var ok bool
+	if data, ok = value.([]byte); !ok {
+		var b bytes.Buffer
+		err := json.NewEncoder(&b).Encode(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode value: %w", err)
+		}
+		data = b.Bytes()
+	}
+
+	err := c.client.Set(ctx, key, data, c.dataExpiration).Err()
+	if err != nil {
+		log.Printf("ошибка записи в кэш: %s\n", err)
+	}
+
+	return nil
+}
+
+func (c *cache) Get(ctx context.Context, key string, ptrValue interface{}) error {
+	b, err := c.client.Get(ctx, key).Bytes()
+
+	if err != nil {
+		if err == redis.Nil {
+			return fmt.Errorf("key %s not found", key)
+		}
+
+		return fmt.Errorf("failed to get key %s: %w", key, err)
+	}
+
+	buffer := bytes.NewBuffer(b)
+
+	log.Printf("чтение из кэша: %s\n", key)
+
+	err = json.NewDecoder(buffer).Decode(ptrValue)
+	if err != nil {
+		return fmt.Errorf("failed to decode key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (c *cache) Delete(ctx context.Context, key string) error {
+	err := c.client.Del(ctx, key).Err()
+	if err != nil {
+		log.Printf("ошибка удаления кэша: %s\n", err)
+		return err
+	}
+	return nil
+}
+
+func (c *cache) Increment(ctx context.Context, key string) (int64, error) {
+	version, err := c.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment key %s: %w", key, err)
+	}
+	return version, nil
+}
@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// lruCache is an in-process Cache backend, used when no Redis instance is
+// configured. It bounds memory usage by evicting the least recently used
+// entry once size is exceeded, and entries also expire after ttl.
+type lruCache struct {
+	mu       sync.Mutex
+	size     int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	eviction *list.List
+}
+
+type lruEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time
+}
+
+// NewLRUCache returns a bounded, TTL-expiring in-memory Cache. size <= 0
+// means unbounded.
+func NewLRUCache(size int, ttl time.Duration) Cache {
+	return &lruCache{
+		size:     size,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		eviction: list.New(),
+	}
+}
+
+func (c *lruCache) Set(_ context.Context, key string, value interface{}) error {
+	var data []byte
+	var ok bool
+	if data, ok = value.([]byte); !ok {
+		var b bytes.Buffer
+		if err := json.NewEncoder(&b).Encode(value); err != nil {
+			return fmt.Errorf("failed to encode value: %w", err)
+		}
+		data = b.Bytes()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setLocked(key, data)
+	return nil
+}
+
+// setLocked upserts key's entry with data, moving it to the front of the
+// eviction list. Callers must hold c.mu.
+func (c *lruCache) setLocked(key string, data []byte) {
+	entry := &lruEntry{key: key, data: data, expiresAt: time.Now().Add(c.ttl)}
+
+	if el, found := c.entries[key]; found {
+		el.Value = entry
+		c.eviction.MoveToFront(el)
+		return
+	}
+
+	el := c.eviction.PushFront(entry)
+	c.entries[key] = el
+
+	if c.size > 0 && c.eviction.Len() > c.size {
+		c.evictOldest()
+	}
+}
+
+func (c *lruCache) Get(_ context.Context, key string, ptrValue interface{}) error {
+	c.mu.Lock()
+	el, found := c.entries[key]
+	if !found {
+		c.mu.Unlock()
+		return fmt.Errorf("key %s not found", key)
+	}
+
+	entry := el.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(el)
+		c.mu.Unlock()
+		return fmt.Errorf("key %s not found", key)
+	}
+
+	c.eviction.MoveToFront(el)
+	data := entry.data
+	c.mu.Unlock()
+
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(ptrValue); err != nil {
+		return fmt.Errorf("failed to decode key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (c *lruCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, found := c.entries[key]; found {
+		c.removeElement(el)
+	}
+
+	return nil
+}
+
+// Increment reads key's current value out of the same entry store Get/Set
+// use, so a later Get sees the bumped value instead of a disjoint counter.
+func (c *lruCache) Increment(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var current int64
+	if el, found := c.entries[key]; found {
+		entry := el.Value.(*lruEntry)
+		if !time.Now().After(entry.expiresAt) {
+			if err := json.Unmarshal(entry.data, &current); err != nil {
+				return 0, fmt.Errorf("failed to decode counter %s: %w", key, err)
+			}
+		}
+	}
+
+	current++
+
+	data, err := json.Marshal(current)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode counter %s: %w", key, err)
+	}
+	c.setLocked(key, data)
+
+	return current, nil
+}
+
+func (c *lruCache) evictOldest() {
+	oldest := c.eviction.Back()
+	if oldest != nil {
+		c.removeElement(oldest)
+	}
+}
+
+func (c *lruCache) removeElement(el *list.Element) {
+	c.eviction.Remove(el)
+	entry := el.Value.(*lruEntry)
+	delete(c.entries, entry.key)
+}
@@ -0,0 +1,108 @@
+// Package config loads the service's runtime configuration from environment
+// variables, replacing the hardcoded addresses/TTLs the service started with.
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env/v10"
+)
+
+const (
+	DriverSQLite   = "sqlite3"
+	DriverPostgres = "postgres"
+)
+
+// Config holds every value that used to be hardcoded in main.go.
+type Config struct {
+	HTTPAddr string `env:"HTTP_ADDR" envDefault:":8080"`
+	GRPCAddr string `env:"GRPC_ADDR" envDefault:":9090"`
+
+	DBDriver string `env:"DB_DRIVER" envDefault:"sqlite3"`
+	DBDSN    string `env:"DB_DSN" envDefault:"./test.db"`
+
+	RedisAddr     string `env:"REDIS_ADDR"`
+	RedisPassword string `env:"REDIS_PASSWORD"`
+	RedisDB       int    `env:"REDIS_DB" envDefault:"0"`
+
+	CacheTTL     time.Duration `env:"CACHE_TTL" envDefault:"5m"`
+	LRUCacheSize int           `env:"LRU_CACHE_SIZE" envDefault:"1000"`
+
+	LogLevel string `env:"LOG_LEVEL" envDefault:"info"`
+
+	JWTSecret string        `env:"JWT_SECRET"`
+	JWTTTL    time.Duration `env:"JWT_TTL" envDefault:"24h"`
+}
+
+// Load reads Config from the environment and validates it, returning a
+// descriptive error that lists every invalid or missing key instead of
+// failing on the first one.
+func Load() (*Config, error) {
+	cfg := &Config{}
+	if err := env.Parse(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config from environment: %w", err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	var problems []string
+
+	if c.HTTPAddr == "" {
+		problems = append(problems, "HTTP_ADDR must not be empty")
+	}
+	if c.GRPCAddr == "" {
+		problems = append(problems, "GRPC_ADDR must not be empty")
+	}
+
+	switch c.DBDriver {
+	case DriverSQLite, DriverPostgres:
+	default:
+		problems = append(problems, fmt.Sprintf("DB_DRIVER must be %q or %q, got %q", DriverSQLite, DriverPostgres, c.DBDriver))
+	}
+	if c.DBDSN == "" {
+		problems = append(problems, "DB_DSN must not be empty")
+	}
+
+	if c.RedisDB < 0 {
+		problems = append(problems, "REDIS_DB must not be negative")
+	}
+	if c.CacheTTL <= 0 {
+		problems = append(problems, "CACHE_TTL must be a positive duration")
+	}
+	if c.LRUCacheSize <= 0 {
+		problems = append(problems, "LRU_CACHE_SIZE must be positive")
+	}
+
+	switch strings.ToLower(c.LogLevel) {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL must be one of debug/info/warn/error, got %q", c.LogLevel))
+	}
+
+	if c.JWTSecret == "" {
+		problems = append(problems, "JWT_SECRET must not be empty")
+	}
+	if c.JWTTTL <= 0 {
+		problems = append(problems, "JWT_TTL must be a positive duration")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+
+	return nil
+}
+
+// UseRedis reports whether a real Redis instance should be used instead of
+// the in-process LRU cache fallback.
+func (c *Config) UseRedis() bool {
+	return c.RedisAddr != ""
+}
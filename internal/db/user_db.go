@@ -0,0 +1,276 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/auth"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/config"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/model"
+)
+
+// UserDB is the lowest-level storage abstraction: a thin wrapper around the
+// SQL driver that knows how to persist and read back a User.
+type UserDB interface {
+	Create(ctx context.Context, user model.User) (int, error)
+	// GetAll returns the page of users matching query, along with the total
+	// count of users matching its filters (ignoring Limit/Offset).
+	GetAll(ctx context.Context, query model.ListUsersQuery) (users []model.User, total int, err error)
+	// GetByEmail looks up a single user by their exact email, used by the
+	// login flow. It returns apperr.CodeNotFound if no such user exists.
+	GetByEmail(ctx context.Context, email string) (model.User, error)
+}
+
+// sortColumns whitelists the columns ORDER BY may reference, keyed by the
+// model.SortBy* values accepted from transports, so user input never reaches
+// the query string directly.
+var sortColumns = map[string]string{
+	model.SortByID:    "id",
+	model.SortByName:  "name",
+	model.SortByEmail: "email",
+	model.SortByAge:   "age",
+}
+
+type userDb struct {
+	db        *sql.DB
+	driver    string
+	TableName string
+}
+
+// NewUserDB wraps sqlDB for the given driver (config.DriverSQLite or
+// config.DriverPostgres) and runs its migrations. driver controls both the
+// DDL Migrate runs and the placeholder style/insert strategy Create uses,
+// since SQLite and Postgres disagree on both.
+func NewUserDB(ctx context.Context, sqlDB *sql.DB, driver string) UserDB {
+	u := &userDb{db: sqlDB, driver: driver, TableName: "users"}
+	if err := u.Migrate(ctx); err != nil {
+		log.Fatal(err)
+	}
+
+	return u
+}
+
+// placeholder returns the bind-parameter marker for the n-th (1-based)
+// argument in a query, following the driver's own convention: SQLite takes
+// positional "?" everywhere, Postgres requires numbered "$n".
+func (u *userDb) placeholder(n int) string {
+	if u.driver == config.DriverPostgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+func (u *userDb) Migrate(ctx context.Context) error {
+	idColumn := "id INTEGER PRIMARY KEY AUTOINCREMENT"
+	if u.driver == config.DriverPostgres {
+		idColumn = "id SERIAL PRIMARY KEY"
+	}
+
+	_, err := u.db.ExecContext(ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			%s,
+			name VARCHAR(255),
+			password VARCHAR(255),
+			email VARCHAR(255) UNIQUE,
+			age INT,
+			role VARCHAR(32) NOT NULL DEFAULT 'user'
+		)`, u.TableName, idColumn))
+	if err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	if u.driver == config.DriverPostgres {
+		_, err = u.db.ExecContext(ctx, fmt.Sprintf(
+			"ALTER TABLE %s ADD COLUMN IF NOT EXISTS role VARCHAR(32) NOT NULL DEFAULT 'user'", u.TableName))
+		if err != nil {
+			return fmt.Errorf("failed to add role column: %w", err)
+		}
+	} else {
+		// ALTER TABLE ADD COLUMN has no IF NOT EXISTS form in sqlite3, so a
+		// table created before the role column existed is migrated here; the
+		// error is ignored when the column is already present.
+		_, err = u.db.ExecContext(ctx, fmt.Sprintf(
+			"ALTER TABLE %s ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'user'", u.TableName))
+		if err != nil && !isDuplicateColumn(err) {
+			return fmt.Errorf("failed to add role column: %w", err)
+		}
+	}
+
+	_, err = u.db.ExecContext(ctx, fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS idx_%s_email ON %s (email)", u.TableName, u.TableName))
+	if err != nil {
+		return fmt.Errorf("failed to create email index: %w", err)
+	}
+
+	_, err = u.db.ExecContext(ctx, fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS idx_%s_age ON %s (age)", u.TableName, u.TableName))
+	if err != nil {
+		return fmt.Errorf("failed to create age index: %w", err)
+	}
+
+	return nil
+}
+
+func isDuplicateColumn(err error) bool {
+	return strings.Contains(err.Error(), "duplicate column name")
+}
+
+// isUniqueViolation reports whether err is a Postgres unique-constraint
+// violation (SQLSTATE 23505).
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func (u *userDb) Create(ctx context.Context, user model.User) (int, error) {
+	hashed, err := auth.HashPassword(user.Password)
+	if err != nil {
+		return 0, apperr.Internal("failed to hash password", err)
+	}
+
+	role := user.Role
+	if role == "" {
+		role = auth.RoleUser
+	}
+
+	if u.driver == config.DriverPostgres {
+		stmt := fmt.Sprintf(
+			"INSERT INTO %s (name, password, email, age, role) VALUES (%s, %s, %s, %s, %s) RETURNING id",
+			u.TableName, u.placeholder(1), u.placeholder(2), u.placeholder(3), u.placeholder(4), u.placeholder(5))
+
+		var id int
+		err := u.db.QueryRowContext(ctx, stmt, user.Name, hashed, user.Email, user.Age, role).Scan(&id)
+		if err != nil {
+			if isUniqueViolation(err) {
+				return 0, apperr.AlreadyExists(fmt.Sprintf("user with email %q already exists", user.Email))
+			}
+			return 0, apperr.Internal("failed to insert user", err)
+		}
+		return id, nil
+	}
+
+	stmt := fmt.Sprintf(
+		"INSERT INTO %s (name, password, email, age, role) VALUES (?, ?, ?, ?, ?)", u.TableName)
+
+	result, err := u.db.ExecContext(ctx, stmt, user.Name, hashed, user.Email, user.Age, role)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return 0, apperr.AlreadyExists(fmt.Sprintf("user with email %q already exists", user.Email))
+		}
+		return 0, apperr.Internal("failed to insert user", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, apperr.Internal("failed to retrieve last insert id", err)
+	}
+	return int(id), nil
+}
+
+func (u *userDb) GetAll(ctx context.Context, query model.ListUsersQuery) ([]model.User, int, error) {
+	where, args := u.whereClause(query)
+
+	total, err := u.count(ctx, where, args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orderBy := sortColumns[query.SortBy]
+	orderDir := "ASC"
+	if query.SortOrder == model.SortOrderDesc {
+		orderDir = "DESC"
+	}
+
+	stmt := fmt.Sprintf("SELECT id, name, password, email, age, role FROM %s%s ORDER BY %s %s LIMIT %s OFFSET %s",
+		u.TableName, where, orderBy, orderDir, u.placeholder(len(args)+1), u.placeholder(len(args)+2))
+
+	rows, err := u.db.QueryContext(ctx, stmt, append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		return nil, 0, apperr.Internal("failed to get all users", err)
+	}
+	defer rows.Close()
+
+	var users []model.User
+	for rows.Next() {
+		var user model.User
+		if err := rows.Scan(&user.Id, &user.Name, &user.Password, &user.Email, &user.Age, &user.Role); err != nil {
+			return nil, 0, apperr.Internal("failed to scan user", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, total, nil
+}
+
+// GetByEmail looks up a single user by their exact email, used by the login
+// flow to verify credentials.
+func (u *userDb) GetByEmail(ctx context.Context, email string) (model.User, error) {
+	var user model.User
+
+	stmt := fmt.Sprintf("SELECT id, name, password, email, age, role FROM %s WHERE email = %s", u.TableName, u.placeholder(1))
+	err := u.db.QueryRowContext(ctx, stmt, email).
+		Scan(&user.Id, &user.Name, &user.Password, &user.Email, &user.Age, &user.Role)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return model.User{}, apperr.NotFound(fmt.Sprintf("user with email %q not found", email))
+	case err != nil:
+		return model.User{}, apperr.Internal("failed to get user by email", err)
+	}
+
+	return user, nil
+}
+
+func (u *userDb) count(ctx context.Context, where string, args []interface{}) (int, error) {
+	var total int
+	stmt := fmt.Sprintf("SELECT COUNT(*) FROM %s%s", u.TableName, where)
+	if err := u.db.QueryRowContext(ctx, stmt, args...).Scan(&total); err != nil {
+		return 0, apperr.Internal("failed to count users", err)
+	}
+	return total, nil
+}
+
+// whereClause builds a parametrized WHERE clause (or "" if query has no
+// filters) together with the matching bind arguments. Placeholders are
+// numbered from 1 so callers appending more arguments (e.g. LIMIT/OFFSET)
+// can continue the sequence with u.placeholder(len(args)+1).
+func (u *userDb) whereClause(query model.ListUsersQuery) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if query.EmailPrefix != "" {
+		args = append(args, query.EmailPrefix+"%")
+		conditions = append(conditions, "email LIKE "+u.placeholder(len(args)))
+	}
+	if query.MinAge > 0 {
+		args = append(args, query.MinAge)
+		conditions = append(conditions, "age >= "+u.placeholder(len(args)))
+	}
+	if query.MaxAge > 0 {
+		args = append(args, query.MaxAge)
+		conditions = append(conditions, "age <= "+u.placeholder(len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	where := " WHERE "
+	for i, c := range conditions {
+		if i > 0 {
+			where += " AND "
+		}
+		where += c
+	}
+
+	return where, args
+}
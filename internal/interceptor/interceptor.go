@@ -0,0 +1,150 @@
+// Package interceptor holds the cross-cutting gRPC unary interceptors
+// (logging, recovery, request-id propagation) so that every transport
+// observes the same behavior for every call.
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/auth"
+)
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the request id propagated by
+// RequestID, or "" if none is set.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestID assigns a request id to every unary call: it reuses the
+// "x-request-id" metadata value sent by the client when present, otherwise it
+// generates a new one, and stores it both in the context and in the outgoing
+// metadata so the same id can be logged by the handler and returned to the
+// caller.
+func RequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		requestID := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("x-request-id"); len(values) > 0 {
+				requestID = values[0]
+			}
+		}
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+		_ = grpc.SetHeader(ctx, metadata.Pairs("x-request-id", requestID))
+
+		return handler(ctx, req)
+	}
+}
+
+// Logging logs the method, duration and outcome of every unary call.
+func Logging() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		log.Printf("grpc [%s] method=%s duration=%s err=%v\n",
+			RequestIDFromContext(ctx), info.FullMethod, time.Since(start), err)
+
+		return resp, err
+	}
+}
+
+// Recovery turns a panic in a handler into a codes.Internal error instead of
+// crashing the server.
+func Recovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("grpc [%s] panic in method=%s: %v\n", RequestIDFromContext(ctx), info.FullMethod, r)
+				err = status.Error(codes.Internal, fmt.Sprintf("internal error: %v", r))
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// Auth enforces the same bearer-token + role policy the HTTP transport
+// enforces via RequireAuth/RequireRole. requiredRoles maps a FullMethod (see
+// the UserService_*_FullMethodName constants) to the role it requires; a
+// method absent from requiredRoles is left public.
+func Auth(issuer auth.TokenIssuer, requiredRoles map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		role, protected := requiredRoles[info.FullMethod]
+		if !protected {
+			return handler(ctx, req)
+		}
+
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, toStatus(apperr.Unauthenticated("отсутствует токен авторизации"))
+		}
+
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, toStatus(apperr.Unauthenticated("отсутствует токен авторизации"))
+		}
+
+		token, ok := strings.CutPrefix(values[0], "Bearer ")
+		if !ok || token == "" {
+			return nil, toStatus(apperr.Unauthenticated("отсутствует токен авторизации"))
+		}
+
+		claims, err := issuer.Parse(token)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+
+		if claims.Role != role {
+			return nil, toStatus(apperr.Forbidden("недостаточно прав для выполнения операции"))
+		}
+
+		return handler(auth.ContextWithClaims(ctx, claims), req)
+	}
+}
+
+// toStatus translates an apperr.Error into the matching gRPC status, mirroring
+// the transport/grpc package's own helper of the same name (interceptors run
+// before a userServer exists to delegate to).
+func toStatus(err error) error {
+	var appErr *apperr.Error
+	if !errors.As(err, &appErr) {
+		return status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+
+	code := codes.Internal
+	switch appErr.Code {
+	case apperr.CodeValidationFailed:
+		code = codes.InvalidArgument
+	case apperr.CodeAlreadyExists:
+		code = codes.AlreadyExists
+	case apperr.CodeConflict:
+		code = codes.Aborted
+	case apperr.CodeNotFound:
+		code = codes.NotFound
+	case apperr.CodeUnauthenticated:
+		code = codes.Unauthenticated
+	case apperr.CodeForbidden:
+		code = codes.PermissionDenied
+	}
+
+	return status.Error(code, appErr.Message)
+}
@@ -0,0 +1,61 @@
+package model
+
+const (
+	SortByID    = "id"
+	SortByName  = "name"
+	SortByEmail = "email"
+	SortByAge   = "age"
+
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+
+	DefaultLimit = 20
+	MaxLimit     = 200
+)
+
+// ListUsersQuery describes the pagination, sorting and filtering options
+// accepted by GetAll across every layer (db, repo, service, transports).
+type ListUsersQuery struct {
+	Limit       int
+	Offset      int
+	SortBy      string
+	SortOrder   string
+	EmailPrefix string
+	MinAge      int
+	MaxAge      int
+}
+
+// Normalize fills in defaults and clamps out-of-range values, so every layer
+// downstream of the transport can assume a well-formed query.
+func (q ListUsersQuery) Normalize() ListUsersQuery {
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+
+	switch q.SortBy {
+	case SortByID, SortByName, SortByEmail, SortByAge:
+	default:
+		q.SortBy = SortByID
+	}
+
+	switch q.SortOrder {
+	case SortOrderAsc, SortOrderDesc:
+	default:
+		q.SortOrder = SortOrderAsc
+	}
+
+	return q
+}
+
+// PagedUsers is the paged response shape returned by UserService.GetAll.
+type PagedUsers struct {
+	Items      []User `json:"items"`
+	Total      int    `json:"total"`
+	NextOffset int    `json:"next_offset"`
+}
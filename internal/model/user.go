@@ -0,0 +1,15 @@
+package model
+
+// User is the core domain entity shared by every transport and storage layer.
+type User struct {
+	Id   int    `json:"id" db:"id,primarykey,autoincrement"`
+	Name string `json:"name" db:"name" validate:"required,min=1,max=255"`
+	// Password is never serialized back to a client; json:"-" keeps the
+	// bcrypt hash out of every response that embeds a User (e.g. GetAll).
+	Password string `json:"-" db:"password" validate:"required,min=8"`
+	Email    string `json:"email" db:"email,unique" validate:"required,email"`
+	Age      int    `json:"age" db:"age" validate:"gte=18,lte=130"`
+	// Role gates access to admin-only operations (see internal/auth). It
+	// defaults to "user" and is never taken from client input.
+	Role string `json:"role" db:"role" validate:"omitempty,oneof=user admin"`
+}
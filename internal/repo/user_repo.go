@@ -0,0 +1,135 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/cache"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/db"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/model"
+)
+
+// usersVersionKey holds the cache version bumped on every write; it is
+// folded into every GetAll cache key so a Create invalidates all of them
+// without having to enumerate or scan existing keys.
+const usersVersionKey = "users:version"
+
+// UserRepo is the repository boundary the service layer depends on, decoupled
+// from any caching concern.
+type UserRepo interface {
+	Create(ctx context.Context, user model.User) (int, error)
+	GetAll(ctx context.Context, query model.ListUsersQuery) (users []model.User, total int, err error)
+	// GetByEmail looks up a single user by their exact email, used by the
+	// login flow. It is never cached, since login is infrequent per user and
+	// correctness (a password change must be seen immediately) matters more
+	// than shaving its latency.
+	GetByEmail(ctx context.Context, email string) (model.User, error)
+}
+
+type userRepo struct {
+	db db.UserDB
+}
+
+func NewUserRepo(userDB db.UserDB) UserRepo {
+	return &userRepo{userDB}
+}
+
+func (ur *userRepo) Create(ctx context.Context, user model.User) (int, error) {
+	return ur.db.Create(ctx, user)
+}
+
+func (ur *userRepo) GetAll(ctx context.Context, query model.ListUsersQuery) ([]model.User, int, error) {
+	return ur.db.GetAll(ctx, query)
+}
+
+func (ur *userRepo) GetByEmail(ctx context.Context, email string) (model.User, error) {
+	return ur.db.GetByEmail(ctx, email)
+}
+
+// proxyUserRepo adds a caching layer in front of UserRepo (Proxy pattern).
+// sf collapses concurrent cache-miss GetAll calls into a single underlying
+// query, so a stampede of requests against a cold/expired cache entry does
+// not turn into N identical DB reads.
+type proxyUserRepo struct {
+	repo  UserRepo
+	cache cache.Cache
+	sf    singleflight.Group
+}
+
+func NewProxyUserRepo(repo UserRepo, c cache.Cache) UserRepo {
+	return &proxyUserRepo{repo: repo, cache: c}
+}
+
+func (p *proxyUserRepo) Create(ctx context.Context, user model.User) (int, error) {
+	id, err := p.repo.Create(ctx, user)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := p.cache.Increment(ctx, usersVersionKey); err != nil {
+		log.Printf("ошибка инвалидации кэша: %s\n", err)
+	}
+
+	return id, nil
+}
+
+type cachedPage struct {
+	Items []model.User
+	Total int
+}
+
+func (p *proxyUserRepo) GetAll(ctx context.Context, query model.ListUsersQuery) ([]model.User, int, error) {
+	key := p.cacheKey(ctx, query)
+
+	var page cachedPage
+	if err := p.cache.Get(ctx, key, &page); err == nil {
+		return page.Items, page.Total, nil
+	}
+
+	result, err, _ := p.sf.Do(key, func() (interface{}, error) {
+		items, total, err := p.repo.GetAll(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		page := cachedPage{Items: items, Total: total}
+		if err := p.cache.Set(ctx, key, page); err != nil {
+			log.Print(err)
+		}
+
+		return page, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	found := result.(cachedPage)
+	return found.Items, found.Total, nil
+}
+
+// cacheKey folds the current write-version and a hash of query's fields into
+// a single key, so a Create invalidates every cached page without the proxy
+// having to know which keys exist.
+func (p *proxyUserRepo) cacheKey(ctx context.Context, query model.ListUsersQuery) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d|%d|%s|%s|%s|%d|%d",
+		query.Limit, query.Offset, query.SortBy, query.SortOrder, query.EmailPrefix, query.MinAge, query.MaxAge)
+
+	return fmt.Sprintf("users:v%d:%x", p.currentVersion(ctx), h.Sum64())
+}
+
+func (p *proxyUserRepo) GetByEmail(ctx context.Context, email string) (model.User, error) {
+	return p.repo.GetByEmail(ctx, email)
+}
+
+func (p *proxyUserRepo) currentVersion(ctx context.Context) int64 {
+	var version int64
+	if err := p.cache.Get(ctx, usersVersionKey, &version); err == nil {
+		return version
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/model"
+)
+
+// countingRepo wraps UserRepo behaviour for tests, counting GetAll calls. Its
+// GetAll blocks on block until the test closes it, so a test can force many
+// concurrent proxy.GetAll calls to actually overlap instead of each one
+// running to completion before the next is scheduled.
+type countingRepo struct {
+	getAllCalls int32
+	block       chan struct{}
+}
+
+func (r *countingRepo) Create(_ context.Context, _ model.User) (int, error) {
+	return 0, nil
+}
+
+func (r *countingRepo) GetAll(_ context.Context, _ model.ListUsersQuery) ([]model.User, int, error) {
+	atomic.AddInt32(&r.getAllCalls, 1)
+	<-r.block
+	users := []model.User{{Id: 1, Name: "alice"}}
+	return users, len(users), nil
+}
+
+func (r *countingRepo) GetByEmail(_ context.Context, _ string) (model.User, error) {
+	return model.User{}, nil
+}
+
+// emptyCache is a Cache that never has anything (every Get misses), mirroring
+// a cold/expired cache entry.
+type emptyCache struct{}
+
+func (emptyCache) Set(_ context.Context, _ string, _ interface{}) error { return nil }
+func (emptyCache) Get(_ context.Context, key string, _ interface{}) error {
+	return fmt.Errorf("key %s not found", key)
+}
+func (emptyCache) Delete(_ context.Context, _ string) error             { return nil }
+func (emptyCache) Increment(_ context.Context, _ string) (int64, error) { return 1, nil }
+
+func TestProxyUserRepo_GetAll_CollapsesConcurrentMisses(t *testing.T) {
+	repo := &countingRepo{block: make(chan struct{})}
+	proxy := NewProxyUserRepo(repo, emptyCache{})
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			if _, _, err := proxy.GetAll(context.Background(), model.ListUsersQuery{}.Normalize()); err != nil {
+				t.Errorf("GetAll: %s", err)
+			}
+		}()
+	}
+
+	// Give every goroutine a chance to queue up behind the singleflight call
+	// before letting it return, so the other 49 are guaranteed to find it
+	// in-flight instead of racing to start their own.
+	time.Sleep(50 * time.Millisecond)
+	close(repo.block)
+
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&repo.getAllCalls); got != 1 {
+		t.Fatalf("expected exactly 1 underlying GetAll call, got %d", got)
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/auth"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/repo"
+)
+
+// AuthService verifies credentials and issues the JWTs used by both
+// transports to authenticate subsequent requests.
+type AuthService interface {
+	// Login returns a signed JWT for the user identified by email, if
+	// password matches. It returns apperr.CodeUnauthenticated otherwise.
+	Login(ctx context.Context, email, password string) (string, error)
+}
+
+type authService struct {
+	repo   repo.UserRepo
+	issuer auth.TokenIssuer
+}
+
+func NewAuthService(userRepo repo.UserRepo, issuer auth.TokenIssuer) AuthService {
+	return &authService{repo: userRepo, issuer: issuer}
+}
+
+func (as *authService) Login(ctx context.Context, email, password string) (string, error) {
+	user, err := as.repo.GetByEmail(ctx, email)
+	if err != nil {
+		// A missing account must look identical to a wrong password, so a
+		// caller can't use Login to probe which emails are registered.
+		if apperr.CodeOf(err) == apperr.CodeNotFound {
+			return "", apperr.Unauthenticated("неверный email или пароль")
+		}
+		return "", err
+	}
+
+	if err := auth.ComparePassword(user.Password, password); err != nil {
+		return "", err
+	}
+
+	return as.issuer.Issue(user.Id, user.Role)
+}
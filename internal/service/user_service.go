@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/auth"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/model"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/repo"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/validation"
+)
+
+// UserService holds the business rules shared by every transport (REST,
+// gRPC, ...).
+type UserService interface {
+	Create(ctx context.Context, user model.User) (int, error)
+	GetAll(ctx context.Context, query model.ListUsersQuery) (model.PagedUsers, error)
+}
+
+type userService struct {
+	repo      repo.UserRepo
+	validator validation.Validator
+}
+
+func NewUserService(userRepo repo.UserRepo, validator validation.Validator) UserService {
+	return &userService{repo: userRepo, validator: validator}
+}
+
+func (us *userService) Create(ctx context.Context, user model.User) (int, error) {
+	if user.Role == "" {
+		user.Role = auth.RoleUser
+	}
+
+	//email можно поставить как уникальное поле, дубликат упадет на db; остальные правила проверяет validator
+	if err := us.validator.Validate(user); err != nil {
+		return 0, err
+	}
+
+	return us.repo.Create(ctx, user)
+}
+
+func (us *userService) GetAll(ctx context.Context, query model.ListUsersQuery) (model.PagedUsers, error) {
+	query = query.Normalize()
+
+	users, total, err := us.repo.GetAll(ctx, query)
+	if err != nil {
+		return model.PagedUsers{}, err
+	}
+
+	nextOffset := query.Offset + len(users)
+	if nextOffset >= total {
+		nextOffset = 0
+	}
+
+	return model.PagedUsers{Items: users, Total: total, NextOffset: nextOffset}, nil
+}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	userv1 "github.com/Rodin-Anatoliy/clean-arch-api-serv/proto/user/v1"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/model"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/service"
+)
+
+// toStatus translates an apperr.Error into the matching gRPC status, the
+// same way the HTTP controller translates it into a status code.
+func toStatus(err error) error {
+	var appErr *apperr.Error
+	if !errors.As(err, &appErr) {
+		return status.Error(codes.Internal, "внутренняя ошибка сервера")
+	}
+
+	code := codes.Internal
+	switch appErr.Code {
+	case apperr.CodeValidationFailed:
+		code = codes.InvalidArgument
+	case apperr.CodeAlreadyExists:
+		code = codes.AlreadyExists
+	case apperr.CodeConflict:
+		code = codes.Aborted
+	case apperr.CodeNotFound:
+		code = codes.NotFound
+	case apperr.CodeUnauthenticated:
+		code = codes.Unauthenticated
+	case apperr.CodeForbidden:
+		code = codes.PermissionDenied
+	}
+
+	return status.Error(code, appErr.Message)
+}
+
+// userServer adapts UserService to the generated gRPC service interface, the
+// same way userController adapts it to net/http.
+type userServer struct {
+	userv1.UnimplementedUserServiceServer
+	service service.UserService
+}
+
+// NewUserServer returns a userv1.UserServiceServer backed by the shared
+// UserService, ready to be registered on a *grpc.Server.
+func NewUserServer(userService service.UserService) userv1.UserServiceServer {
+	return &userServer{service: userService}
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.CreateUserResponse, error) {
+	id, err := s.service.Create(ctx, model.User{
+		Name:     req.GetName(),
+		Password: req.GetPassword(),
+		Email:    req.GetEmail(),
+		Age:      int(req.GetAge()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &userv1.CreateUserResponse{Id: int64(id)}, nil
+}
+
+func (s *userServer) ListUsers(ctx context.Context, req *userv1.ListUsersRequest) (*userv1.ListUsersResponse, error) {
+	page, err := s.service.GetAll(ctx, model.ListUsersQuery{
+		Limit:       int(req.GetLimit()),
+		Offset:      int(req.GetOffset()),
+		SortBy:      req.GetSortBy(),
+		SortOrder:   req.GetSortOrder(),
+		EmailPrefix: req.GetEmailPrefix(),
+		MinAge:      int(req.GetMinAge()),
+		MaxAge:      int(req.GetMaxAge()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	items := make([]*userv1.User, 0, len(page.Items))
+	for _, u := range page.Items {
+		items = append(items, &userv1.User{
+			Id:    int64(u.Id),
+			Name:  u.Name,
+			Email: u.Email,
+			Age:   int32(u.Age),
+		})
+	}
+
+	return &userv1.ListUsersResponse{
+		Items:      items,
+		Total:      int32(page.Total),
+		NextOffset: int32(page.NextOffset),
+	}, nil
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/service"
+)
+
+// AuthController is the REST transport for AuthService.
+type AuthController interface {
+	Login(w http.ResponseWriter, r *http.Request)
+}
+
+type authController struct {
+	service service.AuthService
+}
+
+func NewAuthController(authService service.AuthService) AuthController {
+	return &authController{authService}
+}
+
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type loginResponse struct {
+	Token string `json:"token"`
+}
+
+func (ac *authController) Login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("ошибка декодирования тела запроса: %s\n", err)
+		writeError(w, apperr.ValidationFailed("не удалось разобрать тело запроса"))
+		return
+	}
+
+	token, err := ac.service.Login(r.Context(), req.Email, req.Password)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(loginResponse{Token: token})
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/auth"
+)
+
+// RequireAuth parses the "Authorization: Bearer <token>" header, validates it
+// with issuer and attaches its claims to the request context for downstream
+// handlers (and RequireRole) to read back via auth.ClaimsFromContext. A
+// missing or invalid token is rejected with 401 before next is ever called.
+func RequireAuth(issuer auth.TokenIssuer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || token == "" {
+				writeError(w, apperr.Unauthenticated("отсутствует токен авторизации"))
+				return
+			}
+
+			claims, err := issuer.Parse(token)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+
+			ctx := auth.ContextWithClaims(r.Context(), claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireRole rejects a request with 403 unless the claims attached by
+// RequireAuth carry the given role. It must run after RequireAuth.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := auth.ClaimsFromContext(r.Context())
+			if !ok || claims.Role != role {
+				writeError(w, apperr.Forbidden("недостаточно прав для выполнения операции"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
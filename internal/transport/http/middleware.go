@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the request id attached by the RequestID
+// middleware, or "" if none is set.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestID is the HTTP counterpart of the gRPC interceptor with the same
+// name: it reuses the X-Request-Id header when present, otherwise generates
+// one, and echoes it back on the response.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get("X-Request-Id")
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set("X-Request-Id", requestID)
+		ctx := context.WithValue(r.Context(), requestIDKey{}, requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Logging logs the request id, method, path and duration of every request.
+// The response status is not logged.
+func Logging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+
+		log.Printf("http [%s] %s %s duration=%s\n",
+			RequestIDFromContext(r.Context()), r.Method, r.URL.Path, time.Since(start))
+	})
+}
+
+// Recovery turns a panic in a handler into a 500 instead of crashing the
+// server.
+func Recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.Printf("http [%s] panic: %v\n", RequestIDFromContext(r.Context()), rec)
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("internal error: %v", rec)))
+			}
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Chain applies the repo's standard cross-cutting middleware to next, in the
+// same order the gRPC server applies its interceptors.
+func Chain(next http.Handler) http.Handler {
+	return RequestID(Logging(Recovery(next)))
+}
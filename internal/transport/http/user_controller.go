@@ -0,0 +1,185 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/model"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/service"
+)
+
+// UserController is the REST transport for UserService.
+type UserController interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	GetAll(w http.ResponseWriter, r *http.Request)
+}
+
+type userController struct {
+	service service.UserService
+}
+
+func NewUserController(userService service.UserService) UserController {
+	return &userController{userService}
+}
+
+// errorResponse is the JSON body returned for every failed request that did
+// not fail field validation.
+type errorResponse struct {
+	Code    apperr.Code `json:"code"`
+	Message string      `json:"message"`
+}
+
+// validationErrorResponse is the JSON body returned for a request whose body
+// failed field validation, listing every failing field at once.
+type validationErrorResponse struct {
+	Errors []apperr.FieldError `json:"errors"`
+}
+
+// writeError translates err into the matching HTTP status and writes it as a
+// structured JSON error body, instead of leaking the raw error string. A
+// validation failure that carries field errors is reported as 422 with the
+// full field list; every other error keeps the {code, message} shape.
+func writeError(w http.ResponseWriter, err error) {
+	var appErr *apperr.Error
+	if errors.As(err, &appErr) && appErr.Code == apperr.CodeValidationFailed && len(appErr.Fields) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(validationErrorResponse{Errors: appErr.Fields})
+		return
+	}
+
+	status := http.StatusInternalServerError
+	message := "внутренняя ошибка сервера"
+
+	if errors.As(err, &appErr) {
+		message = appErr.Message
+		switch appErr.Code {
+		case apperr.CodeValidationFailed:
+			status = http.StatusBadRequest
+		case apperr.CodeAlreadyExists, apperr.CodeConflict:
+			status = http.StatusConflict
+		case apperr.CodeNotFound:
+			status = http.StatusNotFound
+		case apperr.CodeUnauthenticated:
+			status = http.StatusUnauthorized
+		case apperr.CodeForbidden:
+			status = http.StatusForbidden
+		default:
+			status = http.StatusInternalServerError
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Code: apperr.CodeOf(err), Message: message})
+}
+
+// createUserRequest is the self-registration request body. It deliberately
+// has no Role field, so a client can never set its own role over the wire;
+// userService.Create always defaults a blank Role to auth.RoleUser.
+type createUserRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Email    string `json:"email"`
+	Age      int    `json:"age"`
+}
+
+func (uc *userController) Create(w http.ResponseWriter, r *http.Request) {
+	var req createUserRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Printf("ошибка декодирования тела запроса: %s\n", err)
+		writeError(w, apperr.ValidationFailed("не удалось разобрать тело запроса"))
+		return
+	}
+
+	user := model.User{Name: req.Name, Password: req.Password, Email: req.Email, Age: req.Age}
+
+	id, err := uc.service.Create(r.Context(), user)
+	if err != nil {
+		log.Printf("ошибка создания юзера: %s\n", err)
+		writeError(w, err)
+		return
+	}
+
+	log.Printf("новый юзер создан c id: %d\n", id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(strconv.Itoa(id)))
+}
+
+func (uc *userController) GetAll(w http.ResponseWriter, r *http.Request) {
+	query, err := parseListUsersQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := uc.service.GetAll(r.Context(), query)
+	if err != nil {
+		log.Printf("ошибка получения юзеров: %s\n", err)
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		log.Printf("ошибка кодирования ответа: %s\n", err)
+		writeError(w, apperr.Internal("ошибка кодирования ответа", err))
+		return
+	}
+
+	log.Println("все юзеры получены")
+}
+
+// parseListUsersQuery reads pagination/filtering/sorting options from the
+// query string. Absent parameters are left zero, so Normalize's defaults
+// apply; a malformed numeric parameter (limit, offset, min_age, max_age) is
+// reported as a validation error.
+func parseListUsersQuery(r *http.Request) (model.ListUsersQuery, error) {
+	q := r.URL.Query()
+	query := model.ListUsersQuery{
+		SortBy:      q.Get("sort_by"),
+		SortOrder:   q.Get("sort_order"),
+		EmailPrefix: q.Get("email_prefix"),
+	}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return query, apperr.ValidationFailed("limit должен быть целым числом")
+		}
+		query.Limit = limit
+	}
+
+	if v := q.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil {
+			return query, apperr.ValidationFailed("offset должен быть целым числом")
+		}
+		query.Offset = offset
+	}
+
+	if v := q.Get("min_age"); v != "" {
+		minAge, err := strconv.Atoi(v)
+		if err != nil {
+			return query, apperr.ValidationFailed("min_age должен быть целым числом")
+		}
+		query.MinAge = minAge
+	}
+
+	if v := q.Get("max_age"); v != "" {
+		maxAge, err := strconv.Atoi(v)
+		if err != nil {
+			return query, apperr.ValidationFailed("max_age должен быть целым числом")
+		}
+		query.MaxAge = maxAge
+	}
+
+	return query, nil
+}
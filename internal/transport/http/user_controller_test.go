@@ -0,0 +1,46 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/model"
+)
+
+// capturingUserService is a fake UserService that records the model.User
+// passed to Create, so a test can inspect exactly what the controller built
+// out of the request body.
+type capturingUserService struct {
+	created model.User
+}
+
+func (s *capturingUserService) Create(_ context.Context, user model.User) (int, error) {
+	s.created = user
+	return 1, nil
+}
+
+func (s *capturingUserService) GetAll(_ context.Context, _ model.ListUsersQuery) (model.PagedUsers, error) {
+	return model.PagedUsers{}, nil
+}
+
+func TestUserController_Create_IgnoresClientSuppliedRole(t *testing.T) {
+	svc := &capturingUserService{}
+	uc := NewUserController(svc)
+
+	body := `{"name":"mallory","password":"hunter2hunter2","email":"mallory@example.com","age":30,"role":"admin"}`
+	req := httptest.NewRequest(http.MethodPost, "/user", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	uc.Create(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	if svc.created.Role != "" {
+		t.Fatalf("expected Role to be ignored, got %q", svc.created.Role)
+	}
+}
@@ -0,0 +1,72 @@
+// Package validation wraps go-playground/validator so every layer reports
+// struct validation failures the same way: as an *apperr.Error carrying one
+// apperr.FieldError per failing field.
+package validation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+)
+
+// Validator validates a struct against its `validate` tags.
+type Validator interface {
+	// Validate returns nil if v is valid, otherwise an *apperr.Error with
+	// Code == apperr.CodeValidationFailed listing every failing field. If v
+	// cannot be validated at all (e.g. it is not a struct), the error has
+	// Code == apperr.CodeInternal instead.
+	Validate(v interface{}) error
+}
+
+type structValidator struct {
+	v *validator.Validate
+}
+
+func New() Validator {
+	return &structValidator{v: validator.New()}
+}
+
+func (sv *structValidator) Validate(v interface{}) error {
+	err := sv.v.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return apperr.Internal("failed to run validation", err)
+	}
+
+	fields := make([]apperr.FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		fields = append(fields, apperr.FieldError{
+			Field:   fe.Field(),
+			Tag:     fe.Tag(),
+			Message: message(fe),
+		})
+	}
+
+	return apperr.ValidationFailedFields("validation failed", fields)
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s обязательно для заполнения", fe.Field())
+	case "email":
+		return fmt.Sprintf("%s должен быть валидным email", fe.Field())
+	case "gte":
+		return fmt.Sprintf("%s должен быть не меньше %s", fe.Field(), fe.Param())
+	case "lte":
+		return fmt.Sprintf("%s должен быть не больше %s", fe.Field(), fe.Param())
+	case "min":
+		return fmt.Sprintf("%s должен быть не короче %s символов", fe.Field(), fe.Param())
+	case "max":
+		return fmt.Sprintf("%s должен быть не длиннее %s символов", fe.Field(), fe.Param())
+	default:
+		return fmt.Sprintf("%s не прошло валидацию (%s)", fe.Field(), fe.Tag())
+	}
+}
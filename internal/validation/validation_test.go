@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/apperr"
+	"github.com/Rodin-Anatoliy/clean-arch-api-serv/internal/model"
+)
+
+func TestValidate_ReportsEveryFailingFieldTogether(t *testing.T) {
+	v := New()
+
+	// Missing name/password, invalid email, under-18 age: every rule should
+	// fail at once and be reported in a single response.
+	user := model.User{
+		Name:     "",
+		Password: "",
+		Email:    "not-an-email",
+		Age:      16,
+	}
+
+	err := v.Validate(user)
+	if err == nil {
+		t.Fatal("expected validation to fail")
+	}
+
+	var appErr *apperr.Error
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected *apperr.Error, got %T", err)
+	}
+	if appErr.Code != apperr.CodeValidationFailed {
+		t.Fatalf("expected CodeValidationFailed, got %s", appErr.Code)
+	}
+
+	gotFields := make(map[string]bool, len(appErr.Fields))
+	for _, fe := range appErr.Fields {
+		gotFields[fe.Field] = true
+	}
+
+	for _, field := range []string{"Name", "Password", "Email", "Age"} {
+		if !gotFields[field] {
+			t.Errorf("expected a field error for %s, got %+v", field, appErr.Fields)
+		}
+	}
+}
+
+func TestValidate_ValidUserPasses(t *testing.T) {
+	v := New()
+
+	user := model.User{
+		Name:     "Alice",
+		Password: "supersecret",
+		Email:    "alice@example.com",
+		Age:      30,
+	}
+
+	if err := v.Validate(user); err != nil {
+		t.Fatalf("expected valid user to pass, got %s", err)
+	}
+}